@@ -0,0 +1,355 @@
+// Package batch streams CSV, TSV or NDJSON rows of coordinates through
+// geoc.ParsePoint and writes them back out in a chosen output format,
+// without buffering the whole input.
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/o-kos/geoc"
+)
+
+// InputFormat selects how Convert parses r.
+type InputFormat string
+
+// Supported input formats. InputAuto sniffs the first bytes of the
+// input to pick one of the others.
+const (
+	InputAuto   InputFormat = "auto"
+	InputCSV    InputFormat = "csv"
+	InputTSV    InputFormat = "tsv"
+	InputNDJSON InputFormat = "ndjson"
+)
+
+// OutputFormat selects how Convert renders each converted point.
+type OutputFormat string
+
+// Supported output formats.
+const (
+	OutputDecimal OutputFormat = "decimal"
+	OutputDMS     OutputFormat = "dms"
+	OutputGeoJSON OutputFormat = "geojson"
+	OutputWKT     OutputFormat = "wkt"
+)
+
+// OnError selects what Convert does with a row it can't parse.
+type OnError string
+
+// Supported error-handling strategies.
+const (
+	OnErrorSkip     OnError = "skip"
+	OnErrorFail     OnError = "fail"
+	OnErrorAnnotate OnError = "annotate"
+)
+
+// Options configures Convert.
+type Options struct {
+	InputFormat InputFormat
+
+	// LatCol and LonCol name the latitude/longitude columns, either by
+	// CSV/TSV header name or by 1-based column index; for NDJSON input
+	// they must be JSON field names. Ignored when CombinedCol is set.
+	LatCol string
+	LonCol string
+
+	// CombinedCol, if set, names a single column holding both
+	// coordinates as "<lat>; <lon>", e.g. "48-33-27N; 120-57-49E".
+	CombinedCol string
+
+	OutputFormat OutputFormat
+	OnError      OnError
+}
+
+func (o *Options) setDefaults() {
+	if o.LatCol == "" && o.CombinedCol == "" {
+		o.LatCol = "lat"
+	}
+	if o.LonCol == "" && o.CombinedCol == "" {
+		o.LonCol = "lon"
+	}
+	if o.OutputFormat == "" {
+		o.OutputFormat = OutputDecimal
+	}
+	if o.OnError == "" {
+		o.OnError = OnErrorFail
+	}
+}
+
+func (o *Options) validate() error {
+	switch o.OutputFormat {
+	case OutputDecimal, OutputDMS, OutputGeoJSON, OutputWKT:
+	default:
+		return fmt.Errorf("batch: unknown output format %q", o.OutputFormat)
+	}
+	switch o.OnError {
+	case OnErrorSkip, OnErrorFail, OnErrorAnnotate:
+	default:
+		return fmt.Errorf("batch: unknown on-error strategy %q", o.OnError)
+	}
+	return nil
+}
+
+// Stats summarizes a Convert run.
+type Stats struct {
+	Rows      int
+	Converted int
+	Errors    int
+}
+
+// Convert reads rows from r, converts their latitude/longitude columns
+// with geoc.ParsePoint, and writes the results to w in the format
+// requested by opts.OutputFormat. It streams row by row and never
+// buffers the whole input, so multi-million-row files convert in
+// constant memory.
+func Convert(r io.Reader, w io.Writer, opts Options) (Stats, error) {
+	opts.setDefaults()
+	if err := opts.validate(); err != nil {
+		return Stats{}, err
+	}
+
+	br := bufio.NewReader(r)
+	format := opts.InputFormat
+	if format == InputAuto || format == "" {
+		detected, err := detectFormat(br)
+		if err != nil {
+			return Stats{}, fmt.Errorf("batch: detecting input format: %v", err)
+		}
+		format = detected
+	}
+
+	switch format {
+	case InputNDJSON:
+		return convertNDJSON(br, w, opts)
+	case InputTSV:
+		return convertDelimited(br, w, opts, '\t')
+	case InputCSV:
+		return convertDelimited(br, w, opts, ',')
+	default:
+		return Stats{}, fmt.Errorf("batch: unknown input format %q", format)
+	}
+}
+
+func detectFormat(br *bufio.Reader) (InputFormat, error) {
+	first, err := br.Peek(1)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	if len(first) > 0 && first[0] == '{' {
+		return InputNDJSON, nil
+	}
+
+	line, err := br.Peek(4096)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	if idx := bytes.IndexByte(line, '\n'); idx != -1 {
+		line = line[:idx]
+	}
+	if bytes.Count(line, []byte{'\t'}) > bytes.Count(line, []byte{','}) {
+		return InputTSV, nil
+	}
+	return InputCSV, nil
+}
+
+func resolveColumn(header []string, spec string) (int, error) {
+	if idx, err := strconv.Atoi(spec); err == nil {
+		if idx < 1 || idx > len(header) {
+			return -1, fmt.Errorf("column index %d out of range (have %d columns)", idx, len(header))
+		}
+		return idx - 1, nil
+	}
+	for i, h := range header {
+		if strings.EqualFold(h, spec) {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("column %q not found in header", spec)
+}
+
+func resolveColumns(header []string, opts Options) (latIdx, lonIdx, combinedIdx int, err error) {
+	if opts.CombinedCol != "" {
+		combinedIdx, err = resolveColumn(header, opts.CombinedCol)
+		return -1, -1, combinedIdx, err
+	}
+	if latIdx, err = resolveColumn(header, opts.LatCol); err != nil {
+		return
+	}
+	lonIdx, err = resolveColumn(header, opts.LonCol)
+	return latIdx, lonIdx, -1, err
+}
+
+func convertDelimited(r io.Reader, w io.Writer, opts Options, comma rune) (Stats, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return Stats{}, fmt.Errorf("batch: reading header: %v", err)
+	}
+
+	latIdx, lonIdx, combinedIdx, err := resolveColumns(header, opts)
+	if err != nil {
+		return Stats{}, fmt.Errorf("batch: %v", err)
+	}
+
+	var stats Stats
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("batch: reading row %d: %v", stats.Rows+1, err)
+		}
+		stats.Rows++
+
+		p, convErr := rowToPoint(row, latIdx, lonIdx, combinedIdx)
+		if convErr != nil {
+			stats.Errors++
+			if err := handleError(w, strings.Join(row, string(comma)), convErr, opts); err != nil {
+				return stats, err
+			}
+			continue
+		}
+		if err := writePoint(w, p, opts.OutputFormat); err != nil {
+			return stats, err
+		}
+		stats.Converted++
+	}
+
+	return stats, nil
+}
+
+func rowToPoint(row []string, latIdx, lonIdx, combinedIdx int) (geoc.Point, error) {
+	if combinedIdx >= 0 {
+		if combinedIdx >= len(row) {
+			return geoc.Point{}, fmt.Errorf("row has no column %d", combinedIdx+1)
+		}
+		return parseCombined(row[combinedIdx])
+	}
+	if latIdx >= len(row) || lonIdx >= len(row) {
+		return geoc.Point{}, fmt.Errorf("row is missing lat/lon columns")
+	}
+	return geoc.ParsePoint(row[latIdx], row[lonIdx])
+}
+
+func parseCombined(s string) (geoc.Point, error) {
+	parts := strings.SplitN(s, ";", 2)
+	if len(parts) != 2 {
+		return geoc.Point{}, fmt.Errorf("combined coordinate %q must contain two parts separated by %q", s, ";")
+	}
+	return geoc.ParsePoint(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+}
+
+func convertNDJSON(r io.Reader, w io.Writer, opts Options) (Stats, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	var stats Stats
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		stats.Rows++
+
+		p, convErr := ndjsonRowToPoint(line, opts)
+		if convErr != nil {
+			stats.Errors++
+			if err := handleError(w, line, convErr, opts); err != nil {
+				return stats, err
+			}
+			continue
+		}
+		if err := writePoint(w, p, opts.OutputFormat); err != nil {
+			return stats, err
+		}
+		stats.Converted++
+	}
+	if err := scanner.Err(); err != nil {
+		return stats, fmt.Errorf("batch: reading ndjson: %v", err)
+	}
+
+	return stats, nil
+}
+
+func ndjsonRowToPoint(line string, opts Options) (geoc.Point, error) {
+	var row map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &row); err != nil {
+		return geoc.Point{}, fmt.Errorf("invalid JSON row: %v", err)
+	}
+
+	field := func(name string) (string, error) {
+		v, ok := row[name]
+		if !ok {
+			return "", fmt.Errorf("field %q not found", name)
+		}
+		return fmt.Sprintf("%v", v), nil
+	}
+
+	if opts.CombinedCol != "" {
+		s, err := field(opts.CombinedCol)
+		if err != nil {
+			return geoc.Point{}, err
+		}
+		return parseCombined(s)
+	}
+
+	latStr, err := field(opts.LatCol)
+	if err != nil {
+		return geoc.Point{}, err
+	}
+	lonStr, err := field(opts.LonCol)
+	if err != nil {
+		return geoc.Point{}, err
+	}
+	return geoc.ParsePoint(latStr, lonStr)
+}
+
+func handleError(w io.Writer, original string, convErr error, opts Options) error {
+	switch opts.OnError {
+	case OnErrorFail:
+		return fmt.Errorf("batch: %v", convErr)
+	case OnErrorAnnotate:
+		_, err := fmt.Fprintf(w, "%s\tERROR: %v\n", original, convErr)
+		return err
+	default: // skip
+		return nil
+	}
+}
+
+// writePoint renders p in the requested format, one line per point so
+// GeoJSON output streams as newline-delimited Point geometries rather
+// than a single buffered FeatureCollection.
+func writePoint(w io.Writer, p geoc.Point, format OutputFormat) error {
+	var line string
+	switch format {
+	case OutputDMS:
+		s, err := p.Format(`48-33-27N`, `120-57-49E`, " ")
+		if err != nil {
+			return fmt.Errorf("batch: %v", err)
+		}
+		line = s
+	case OutputGeoJSON:
+		data, err := geoc.MarshalGeoJSON(p)
+		if err != nil {
+			return fmt.Errorf("batch: %v", err)
+		}
+		line = string(data)
+	case OutputWKT:
+		line = geoc.MarshalWKT(p)
+	default:
+		line = fmt.Sprintf("%.6f,%.6f", p.Lat.Value, p.Lon.Value)
+	}
+
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
@@ -0,0 +1,150 @@
+package batch
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvertCSVDecimal(t *testing.T) {
+	input := "name,lat,lon\nLondon,48-33-27N,120-57-49E\n"
+	var out strings.Builder
+
+	stats, err := Convert(strings.NewReader(input), &out, Options{InputFormat: InputCSV})
+	if err != nil {
+		t.Fatalf("Convert returned error: %v", err)
+	}
+	if stats.Rows != 1 || stats.Converted != 1 || stats.Errors != 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if want := "48.557500,120.963611\n"; out.String() != want {
+		t.Errorf("Convert output: got %q, want %q", out.String(), want)
+	}
+}
+
+func TestConvertTSVByIndex(t *testing.T) {
+	input := "name\tx\ty\nLondon\t48-33-27N\t120-57-49E\n"
+	var out strings.Builder
+
+	stats, err := Convert(strings.NewReader(input), &out, Options{
+		InputFormat: InputTSV,
+		LatCol:      "2",
+		LonCol:      "3",
+	})
+	if err != nil {
+		t.Fatalf("Convert returned error: %v", err)
+	}
+	if stats.Converted != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestConvertAutoDetectsTSV(t *testing.T) {
+	input := "lat\tlon\n48-33-27N\t120-57-49E\n"
+	var out strings.Builder
+
+	if _, err := Convert(strings.NewReader(input), &out, Options{}); err != nil {
+		t.Fatalf("Convert returned error: %v", err)
+	}
+	if want := "48.557500,120.963611\n"; out.String() != want {
+		t.Errorf("Convert output: got %q, want %q", out.String(), want)
+	}
+}
+
+func TestConvertCombinedColumn(t *testing.T) {
+	input := "name,where\nLondon,48-33-27N; 120-57-49E\n"
+	var out strings.Builder
+
+	stats, err := Convert(strings.NewReader(input), &out, Options{CombinedCol: "where"})
+	if err != nil {
+		t.Fatalf("Convert returned error: %v", err)
+	}
+	if stats.Converted != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestConvertNDJSON(t *testing.T) {
+	input := `{"lat":"48-33-27N","lon":"120-57-49E"}` + "\n"
+	var out strings.Builder
+
+	stats, err := Convert(strings.NewReader(input), &out, Options{InputFormat: InputNDJSON})
+	if err != nil {
+		t.Fatalf("Convert returned error: %v", err)
+	}
+	if stats.Converted != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestConvertOnErrorSkip(t *testing.T) {
+	input := "lat,lon\nnot-a-coord,120-57-49E\n"
+	var out strings.Builder
+
+	stats, err := Convert(strings.NewReader(input), &out, Options{OnError: OnErrorSkip})
+	if err != nil {
+		t.Fatalf("Convert returned error: %v", err)
+	}
+	if stats.Errors != 1 || stats.Converted != 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if out.String() != "" {
+		t.Errorf("expected no output for skipped row, got %q", out.String())
+	}
+}
+
+func TestConvertOnErrorFail(t *testing.T) {
+	input := "lat,lon\nnot-a-coord,120-57-49E\n"
+	var out strings.Builder
+
+	if _, err := Convert(strings.NewReader(input), &out, Options{OnError: OnErrorFail}); err == nil {
+		t.Error("expected error for bad row with OnErrorFail, got nil")
+	}
+}
+
+func TestConvertOnErrorAnnotate(t *testing.T) {
+	input := "lat,lon\nnot-a-coord,120-57-49E\n"
+	var out strings.Builder
+
+	stats, err := Convert(strings.NewReader(input), &out, Options{OnError: OnErrorAnnotate})
+	if err != nil {
+		t.Fatalf("Convert returned error: %v", err)
+	}
+	if stats.Errors != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if !strings.Contains(out.String(), "ERROR:") {
+		t.Errorf("expected annotated error line, got %q", out.String())
+	}
+}
+
+func TestConvertUnknownOnError(t *testing.T) {
+	input := "lat,lon\n48-33-27N,120-57-49E\n"
+	var out strings.Builder
+
+	if _, err := Convert(strings.NewReader(input), &out, Options{OnError: "faill"}); err == nil {
+		t.Error("expected error for unknown OnError strategy, got nil")
+	}
+}
+
+func TestConvertUnknownOutputFormat(t *testing.T) {
+	input := "lat,lon\n48-33-27N,120-57-49E\n"
+	var out strings.Builder
+
+	if _, err := Convert(strings.NewReader(input), &out, Options{OutputFormat: "dm"}); err == nil {
+		t.Error("expected error for unknown OutputFormat, got nil")
+	}
+}
+
+func TestConvertOutputFormats(t *testing.T) {
+	input := "lat,lon\n48-33-27N,120-57-49E\n"
+
+	for _, format := range []OutputFormat{OutputDMS, OutputGeoJSON, OutputWKT} {
+		var out strings.Builder
+		if _, err := Convert(strings.NewReader(input), &out, Options{OutputFormat: format}); err != nil {
+			t.Errorf("Convert with format %q returned error: %v", format, err)
+		}
+		if out.Len() == 0 {
+			t.Errorf("Convert with format %q produced no output", format)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Command geoc converts geographic coordinates between CSV, TSV and
+// NDJSON files, reading from stdin or a file and writing decimal
+// degrees, DMS, GeoJSON or WKT to stdout.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/o-kos/geoc/batch"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "geoc:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("geoc", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	inputFormat := fs.String("input-format", string(batch.InputAuto), "input format: auto|csv|tsv|ndjson")
+	outputFormat := fs.String("output-format", string(batch.OutputDecimal), "output format: decimal|dms|geojson|wkt")
+	latCol := fs.String("lat-col", "", "latitude column header name or 1-based index (default \"lat\")")
+	lonCol := fs.String("lon-col", "", "longitude column header name or 1-based index (default \"lon\")")
+	combinedCol := fs.String("combined-col", "", `single column holding both coordinates, e.g. "48-33-27N; 120-57-49E"`)
+	onError := fs.String("on-error", string(batch.OnErrorFail), "behaviour on a bad row: skip|fail|annotate")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	in := stdin
+	if fs.NArg() > 0 {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	stats, err := batch.Convert(in, stdout, batch.Options{
+		InputFormat:  batch.InputFormat(*inputFormat),
+		OutputFormat: batch.OutputFormat(*outputFormat),
+		LatCol:       *latCol,
+		LonCol:       *lonCol,
+		CombinedCol:  *combinedCol,
+		OnError:      batch.OnError(*onError),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stderr, "geoc: %d rows, %d converted, %d errors\n", stats.Rows, stats.Converted, stats.Errors)
+	return nil
+}
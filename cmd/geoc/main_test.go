@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunConvertsStdin(t *testing.T) {
+	in := strings.NewReader("lat,lon\n48-33-27N,120-57-49E\n")
+	var out, errOut bytes.Buffer
+
+	if err := run(nil, in, &out, &errOut); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	if want := "48.557500,120.963611\n"; out.String() != want {
+		t.Errorf("stdout: got %q, want %q", out.String(), want)
+	}
+}
+
+func TestRunBadRowFailsByDefault(t *testing.T) {
+	in := strings.NewReader("lat,lon\nnot-a-coord,120-57-49E\n")
+	var out, errOut bytes.Buffer
+
+	if err := run(nil, in, &out, &errOut); err == nil {
+		t.Error("expected error for bad row, got nil")
+	}
+}
+
+func TestRunUnknownFlag(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if err := run([]string{"--not-a-flag"}, strings.NewReader(""), &out, &errOut); err == nil {
+		t.Error("expected error for unknown flag, got nil")
+	}
+}
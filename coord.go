@@ -0,0 +1,150 @@
+package geoc
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Coord is a single geographic coordinate value paired with the axis
+// (latitude or longitude) it was parsed for, so it can be formatted
+// back into a hemisphere-aware string.
+type Coord struct {
+	Value float64
+	Loc   Location
+}
+
+// ParseCoord converts a string presentation of a geographic coordinate
+// to a Coord, recording the axis inferred from the N/S/E/W hemisphere
+// letter, if any. Returns an error if the coordinate string is invalid.
+func ParseCoord(cs string) (Coord, error) {
+	cg, err := newCoordGroups(cs)
+	if err != nil {
+		return Coord{}, fmt.Errorf("%v in string %q", err, cs)
+	}
+
+	value, err := cg.getCoord(None)
+	if err != nil {
+		return Coord{}, fmt.Errorf("%v in string %q", err, cs)
+	}
+
+	return Coord{Value: value, Loc: locFromLetter(cg.loc)}, nil
+}
+
+func locFromLetter(letter string) Location {
+	switch letter {
+	case "N", "S":
+		return Lat
+	case "E", "W":
+		return Lon
+	default:
+		return None
+	}
+}
+
+// String renders the coordinate in compact "DD-MM.mL" form, e.g.
+// "48-33.4N", with one decimal digit of minutes and the hemisphere
+// letter matching c.Loc and the sign of c.Value.
+func (c Coord) String() string {
+	v := math.Abs(c.Value)
+	deg := math.Trunc(v)
+	min := (v - deg) * 60
+
+	return fmt.Sprintf("%d-%.1f%s", int(deg), min, locLetter(c.Loc, c.Value))
+}
+
+// Format renders the coordinate using the degree/minute/second layout,
+// separators and hemisphere-letter style found in layout (itself a
+// valid coordinate string, e.g. `48°33'27"N`), applied to c's own
+// value. This lets callers reuse one coordinate string as a template
+// for formatting arbitrary values.
+func (c Coord) Format(layout string) (string, error) {
+	cg, err := newCoordGroups(layout)
+	if err != nil {
+		return "", fmt.Errorf("%v in layout %q", err, layout)
+	}
+	if _, err := cg.getCoord(None); err != nil {
+		return "", fmt.Errorf("%v in layout %q", err, layout)
+	}
+
+	hasMin, hasSec := cg.min != "", cg.sec != ""
+	v := math.Abs(c.Value)
+	deg := math.Trunc(v)
+	rem := v - deg
+
+	var min, sec float64
+	switch {
+	case hasSec:
+		min = math.Trunc(rem * 60)
+		sec = (rem*60 - min) * 60
+	case hasMin:
+		min = rem * 60
+	default:
+		deg = v
+	}
+
+	var b strings.Builder
+	degDecimals := 0
+	if !hasMin {
+		degDecimals = decimalsOf(cg.deg)
+	}
+	b.WriteString(formatComponent(deg, degDecimals))
+	b.WriteString(cg.sep.deg)
+
+	if hasMin {
+		minDecimals := 0
+		if !hasSec {
+			minDecimals = decimalsOf(cg.min)
+		}
+		b.WriteString(formatComponent(min, minDecimals))
+		b.WriteString(cg.sep.min)
+	}
+
+	if hasSec {
+		b.WriteString(formatComponent(sec, decimalsOf(cg.sec)))
+		b.WriteString(cg.sep.sec)
+	}
+
+	if cg.loc != "" {
+		b.WriteString(locLetter(c.Loc, c.Value))
+	}
+
+	return b.String(), nil
+}
+
+// locLetter returns the hemisphere letter for loc given the sign of
+// value, or "" if loc is None.
+func locLetter(loc Location, value float64) string {
+	switch loc {
+	case Lat:
+		if value < 0 {
+			return "S"
+		}
+		return "N"
+	case Lon:
+		if value < 0 {
+			return "W"
+		}
+		return "E"
+	default:
+		return ""
+	}
+}
+
+// decimalsOf returns the number of digits following the decimal point
+// in a coordGroups numeric string already normalised to use ".".
+func decimalsOf(s string) int {
+	idx := strings.IndexByte(s, '.')
+	if idx == -1 {
+		return 0
+	}
+	return len(s) - idx - 1
+}
+
+func formatComponent(v float64, decimals int) string {
+	if decimals <= 0 {
+		return strconv.FormatFloat(math.Round(v), 'f', 0, 64)
+	}
+	return strconv.FormatFloat(v, 'f', decimals, 64)
+}
@@ -14,7 +14,7 @@ func ExampleParseCoord() {
 }
 
 func ExampleParsePoint() {
-	p, err := ParsePoint("48-33-27N; 120-57-49E")
+	p, err := ParsePoint("48-33-27N", "120-57-49E")
 	if err != nil {
 		fmt.Println("error:", err)
 		return
@@ -43,7 +43,7 @@ func ExampleCoord_String() {
 }
 
 func ExamplePoint_Format() {
-	p, err := ParsePoint("48-33-27N; 120-57-49E")
+	p, err := ParsePoint("48-33-27N", "120-57-49E")
 	if err != nil {
 		fmt.Println("error:", err)
 		return
@@ -1,4 +1,5 @@
-// Package geoc provides geographic coordinate converter from string to native float64.
+// Package geoc provides geographic coordinate parsing, formatting and
+// geospatial math on top of a Point/Coord representation.
 package geoc
 
 import (
@@ -242,41 +243,42 @@ func (cg *coordGroups) getCoord(loc Location) (float64, error) {
 	return coord, nil
 }
 
+// Point is a geographic coordinate pair, each axis keeping track of its
+// own hemisphere so it can be formatted back symmetrically.
 type Point struct {
-	Lat float64
-	Lon float64
+	Lat Coord
+	Lon Coord
 }
 
-func (p *Point) String() string {
-	return fmt.Sprintf(
-		"[%s, %s]",
-		strings.TrimRight(strconv.FormatFloat(p.Lat, 'f', 6, 64), "0"),
-		strings.TrimRight(strconv.FormatFloat(p.Lon, 'f', 6, 64), "0"),
-	)
+func newPoint(latDeg, lonDeg float64) Point {
+	return Point{Coord{latDeg, Lat}, Coord{lonDeg, Lon}}
 }
 
-// StringToCoord converts string presentation
-// of geographic coordinate to native float number.
-// Returns float64 value of coordinate or error
-// if coordinate string is invalid.
-func StringToCoord(cs string) (float64, error) {
-	gc, err := newCoordGroups(cs)
+// String renders p as its two coordinates in compact "DD-MM.mL" form,
+// separated by a space, e.g. "48-33.4N 120-57.8E".
+func (p Point) String() string {
+	return p.Lat.String() + " " + p.Lon.String()
+}
+
+// Format renders p using latLayout and lonLayout as per-axis templates
+// for Coord.Format, joined by sep.
+func (p Point) Format(latLayout, lonLayout, sep string) (string, error) {
+	latStr, err := p.Lat.Format(latLayout)
 	if err != nil {
-		return 0, fmt.Errorf("%v in string %q", err, cs)
+		return "", err
 	}
-
-	coord, err := gc.getCoord(None)
+	lonStr, err := p.Lon.Format(lonLayout)
 	if err != nil {
-		return 0, fmt.Errorf("%v in string %q", err, cs)
+		return "", err
 	}
 
-	return coord, nil
+	return latStr + sep + lonStr, nil
 }
 
-// StringToPoint converts a pair of geographic coordinates string to Point.
-// Returns float64 representation of coordinates or error
-// if coordinate string is invalid.
-func StringToPoint(lat string, lon string) (Point, error) {
+// ParsePoint converts a pair of geographic coordinate strings to a
+// Point. Returns an error if either coordinate string is invalid, or
+// their formats don't match.
+func ParsePoint(lat string, lon string) (Point, error) {
 	retErr := func(err error, str string) (Point, error) {
 		return Point{}, fmt.Errorf("%v in string %q", err, str)
 	}
@@ -303,5 +305,5 @@ func StringToPoint(lat string, lon string) (Point, error) {
 		return Point{}, fmt.Errorf("formats of lat (%q) and lon (%q) strings are not identical", lat, lon)
 	}
 
-	return Point{pt, pn}, nil
+	return Point{Coord{pt, Lat}, Coord{pn, Lon}}, nil
 }
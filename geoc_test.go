@@ -35,7 +35,7 @@ var goodCases = []testCase{
 	{`+48`, 48, false},
 }
 
-func TestStringToCoord(t *testing.T) {
+func TestParseCoord(t *testing.T) {
 	testCases := append(
 		goodCases, []testCase{
 			{`98`, 98, false},
@@ -52,7 +52,7 @@ func TestStringToCoord(t *testing.T) {
 	)
 
 	for _, tc := range testCases {
-		coord, err := StringToCoord(tc.input)
+		coord, err := ParseCoord(tc.input)
 		if tc.expectedError {
 			if err == nil {
 				t.Errorf("Expected error for %q string, got nil", tc.input)
@@ -62,18 +62,18 @@ func TestStringToCoord(t *testing.T) {
 				t.Errorf("Error %v, but excepted %f ", err, tc.expectedCoord)
 				continue
 			}
-			if math.Abs(coord-tc.expectedCoord) > 0.000001 {
-				t.Errorf("For string %q expected coord is %f, but got %f", tc.input, tc.expectedCoord, coord)
+			if math.Abs(coord.Value-tc.expectedCoord) > 0.000001 {
+				t.Errorf("For string %q expected coord is %f, but got %f", tc.input, tc.expectedCoord, coord.Value)
 			}
 		}
 	}
 }
 
-func TestStringToPoint(t *testing.T) {
+func TestParsePoint(t *testing.T) {
 	testCases := append(
 		goodCases,
 		[]testCase{
-			{`48-33N; 048-33.0E`, 48.55, false},
+			{`48-33N; 048-33E`, 48.55, false},
 
 			{`48N; 48N`, 0, true},
 			{`48°33'26,9604"N; 48-33-26.9604E`, 0, true},
@@ -94,7 +94,7 @@ func TestStringToPoint(t *testing.T) {
 		}
 
 		cl := strings.Split(cs, "; ")
-		point, err := StringToPoint(cl[0], cl[1])
+		point, err := ParsePoint(cl[0], cl[1])
 		if tc.expectedError {
 			if err == nil {
 				t.Errorf("Expected error for %q string, got nil", cs)
@@ -105,11 +105,11 @@ func TestStringToPoint(t *testing.T) {
 				continue
 			}
 
-			if math.Abs(point.Lat-tc.expectedCoord) > 0.000001 {
-				t.Errorf("For string %q expected lat is %f, but got %f", cs, tc.expectedCoord, point.Lat)
+			if math.Abs(point.Lat.Value-tc.expectedCoord) > 0.000001 {
+				t.Errorf("For string %q expected lat is %f, but got %f", cs, tc.expectedCoord, point.Lat.Value)
 			}
-			if math.Abs(point.Lat-tc.expectedCoord) > 0.000001 {
-				t.Errorf("For string %q expected lat is %f, but got %f", cs, tc.expectedCoord, point.Lat)
+			if math.Abs(point.Lon.Value-tc.expectedCoord) > 0.000001 {
+				t.Errorf("For string %q expected lon is %f, but got %f", cs, tc.expectedCoord, point.Lon.Value)
 			}
 		}
 	}
@@ -0,0 +1,165 @@
+package geoc
+
+import (
+	"errors"
+	"math"
+)
+
+// meanEarthRadius is the mean radius of the Earth in metres, as used by
+// the great-circle formulae below.
+const meanEarthRadius = 6371008.8
+
+// WGS84 ellipsoid parameters, used by DistanceEllipsoidal.
+const (
+	wgs84SemiMajorAxis = 6378137.0
+	wgs84SemiMinorAxis = 6356752.314245
+	wgs84Flattening    = 1 / 298.257223563
+)
+
+func toRadians(deg float64) float64 { return deg * math.Pi / 180 }
+func toDegrees(rad float64) float64 { return rad * 180 / math.Pi }
+
+// normalizeLon wraps a longitude in degrees into [-180, 180).
+func normalizeLon(deg float64) float64 {
+	return math.Mod(deg+540, 360) - 180
+}
+
+// DistanceTo returns the great-circle distance between p and q, in
+// metres, computed with the Haversine formula on a sphere of radius
+// meanEarthRadius. For sub-metre accuracy on WGS84 use
+// DistanceEllipsoidal instead.
+func (p Point) DistanceTo(q Point) float64 {
+	phi1, phi2 := toRadians(p.Lat.Value), toRadians(q.Lat.Value)
+	dPhi := toRadians(q.Lat.Value - p.Lat.Value)
+	dLambda := toRadians(q.Lon.Value - p.Lon.Value)
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	return meanEarthRadius * c
+}
+
+// InitialBearingTo returns the initial bearing (forward azimuth), in
+// degrees clockwise from true north in the range [0, 360), of the
+// great-circle path from p to q.
+func (p Point) InitialBearingTo(q Point) float64 {
+	phi1, phi2 := toRadians(p.Lat.Value), toRadians(q.Lat.Value)
+	dLambda := toRadians(q.Lon.Value - p.Lon.Value)
+
+	y := math.Sin(dLambda) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLambda)
+
+	return math.Mod(toDegrees(math.Atan2(y, x))+360, 360)
+}
+
+// Destination returns the point reached by travelling distanceMeters
+// along the great circle at initial bearing bearingDeg (degrees
+// clockwise from true north) from p.
+func (p Point) Destination(bearingDeg, distanceMeters float64) Point {
+	phi1 := toRadians(p.Lat.Value)
+	lambda1 := toRadians(p.Lon.Value)
+	theta := toRadians(bearingDeg)
+	delta := distanceMeters / meanEarthRadius
+
+	phi2 := math.Asin(math.Sin(phi1)*math.Cos(delta) + math.Cos(phi1)*math.Sin(delta)*math.Cos(theta))
+	lambda2 := lambda1 + math.Atan2(
+		math.Sin(theta)*math.Sin(delta)*math.Cos(phi1),
+		math.Cos(delta)-math.Sin(phi1)*math.Sin(phi2),
+	)
+
+	return newPoint(toDegrees(phi2), normalizeLon(toDegrees(lambda2)))
+}
+
+// Midpoint returns the geographic midpoint of the great-circle path
+// between p and q.
+func (p Point) Midpoint(q Point) Point {
+	phi1, lambda1 := toRadians(p.Lat.Value), toRadians(p.Lon.Value)
+	phi2 := toRadians(q.Lat.Value)
+	dLambda := toRadians(q.Lon.Value - p.Lon.Value)
+
+	bx := math.Cos(phi2) * math.Cos(dLambda)
+	by := math.Cos(phi2) * math.Sin(dLambda)
+
+	phi3 := math.Atan2(
+		math.Sin(phi1)+math.Sin(phi2),
+		math.Sqrt((math.Cos(phi1)+bx)*(math.Cos(phi1)+bx)+by*by),
+	)
+	lambda3 := lambda1 + math.Atan2(by, math.Cos(phi1)+bx)
+
+	return newPoint(toDegrees(phi3), normalizeLon(toDegrees(lambda3)))
+}
+
+// BoundingBox returns the north-west and south-east corners of the
+// rectangle formed by walking radiusMeters from p in every direction.
+// Longitude is wrapped into [-180, 180); when the box straddles the
+// antimeridian, nw.Lon ends up greater than se.Lon, and callers must
+// split the range into [nw.Lon, 180] and [-180, se.Lon].
+func (p Point) BoundingBox(radiusMeters float64) (nw, se Point) {
+	latDelta := radiusMeters / meanEarthRadius
+	lonDelta := math.Asin(math.Sin(latDelta) / math.Cos(toRadians(p.Lat.Value)))
+
+	maxLat := math.Min(90, toDegrees(toRadians(p.Lat.Value)+latDelta))
+	minLat := math.Max(-90, toDegrees(toRadians(p.Lat.Value)-latDelta))
+	minLon := normalizeLon(p.Lon.Value - toDegrees(lonDelta))
+	maxLon := normalizeLon(p.Lon.Value + toDegrees(lonDelta))
+
+	return newPoint(maxLat, minLon), newPoint(minLat, maxLon)
+}
+
+// DistanceEllipsoidal returns the geodesic distance between p and q, in
+// metres, computed with Vincenty's formula on the WGS84 ellipsoid. It
+// is an opt-in for callers who need sub-metre accuracy; DistanceTo is
+// cheaper and accurate enough for most uses. It returns an error if the
+// iteration fails to converge, which can happen for near-antipodal
+// points.
+func (p Point) DistanceEllipsoidal(q Point) (float64, error) {
+	L := toRadians(q.Lon.Value - p.Lon.Value)
+	U1 := math.Atan((1 - wgs84Flattening) * math.Tan(toRadians(p.Lat.Value)))
+	U2 := math.Atan((1 - wgs84Flattening) * math.Tan(toRadians(q.Lat.Value)))
+	sinU1, cosU1 := math.Sin(U1), math.Cos(U1)
+	sinU2, cosU2 := math.Sin(U2), math.Cos(U2)
+
+	lambda := L
+	var sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM float64
+	converged := false
+	for i := 0; i < 200; i++ {
+		sinLambda, cosLambda := math.Sin(lambda), math.Cos(lambda)
+		sinSigma = math.Sqrt(
+			(cosU2*sinLambda)*(cosU2*sinLambda) +
+				(cosU1*sinU2-sinU1*cosU2*cosLambda)*(cosU1*sinU2-sinU1*cosU2*cosLambda),
+		)
+		if sinSigma == 0 {
+			return 0, nil // coincident points
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+
+		c := wgs84Flattening / 16 * cosSqAlpha * (4 + wgs84Flattening*(4-3*cosSqAlpha))
+		lambdaPrev := lambda
+		lambda = L + (1-c)*wgs84Flattening*sinAlpha*
+			(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-lambdaPrev) < 1e-12 {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return 0, errors.New("geoc: Vincenty formula failed to converge")
+	}
+
+	uSq := cosSqAlpha * (wgs84SemiMajorAxis*wgs84SemiMajorAxis - wgs84SemiMinorAxis*wgs84SemiMinorAxis) / (wgs84SemiMinorAxis * wgs84SemiMinorAxis)
+	a := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	b := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	return wgs84SemiMinorAxis * a * (sigma - deltaSigma), nil
+}
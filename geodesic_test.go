@@ -0,0 +1,84 @@
+package geoc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistanceTo(t *testing.T) {
+	london := newPoint(51.5007, -0.1246)
+	paris := newPoint(48.8566, 2.3522)
+
+	got := london.DistanceTo(paris)
+	want := 343556.0 // metres, per standard Haversine reference calculators
+	if math.Abs(got-want) > 1000 {
+		t.Errorf("DistanceTo: got %.0fm, want ~%.0fm", got, want)
+	}
+	if d := london.DistanceTo(london); d != 0 {
+		t.Errorf("DistanceTo of a point to itself: got %f, want 0", d)
+	}
+}
+
+func TestInitialBearingTo(t *testing.T) {
+	london := newPoint(51.5007, -0.1246)
+	paris := newPoint(48.8566, 2.3522)
+
+	got := london.InitialBearingTo(paris)
+	want := 148.1 // degrees, per standard great-circle bearing reference calculators
+	if math.Abs(got-want) > 1 {
+		t.Errorf("InitialBearingTo: got %.1f, want ~%.1f", got, want)
+	}
+}
+
+func TestDestination(t *testing.T) {
+	start := newPoint(51.5007, -0.1246)
+
+	dest := start.Destination(148.1, 343556)
+	paris := newPoint(48.8566, 2.3522)
+
+	if d := dest.DistanceTo(paris); d > 2000 {
+		t.Errorf("Destination: landed %.0fm away from expected point", d)
+	}
+}
+
+func TestMidpoint(t *testing.T) {
+	a := newPoint(52.205, 0.119)
+	b := newPoint(48.857, 2.351)
+
+	mid := a.Midpoint(b)
+	want := newPoint(50.5363, 1.2746)
+
+	if d := mid.DistanceTo(want); d > 1000 {
+		t.Errorf("Midpoint: got %v, want close to %v (off by %.0fm)", mid, want, d)
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	center := newPoint(51.5007, 0.1246)
+	nw, se := center.BoundingBox(1000)
+
+	if nw.Lat.Value <= center.Lat.Value || se.Lat.Value >= center.Lat.Value {
+		t.Errorf("BoundingBox: nw/se latitude not straddling center: nw=%v se=%v center=%v", nw, se, center)
+	}
+	if nw.Lon.Value >= center.Lon.Value || se.Lon.Value <= center.Lon.Value {
+		t.Errorf("BoundingBox: nw/se longitude not straddling center: nw=%v se=%v center=%v", nw, se, center)
+	}
+}
+
+func TestDistanceEllipsoidal(t *testing.T) {
+	london := newPoint(51.5007, -0.1246)
+	paris := newPoint(48.8566, 2.3522)
+
+	got, err := london.DistanceEllipsoidal(paris)
+	if err != nil {
+		t.Fatalf("DistanceEllipsoidal returned error: %v", err)
+	}
+	want := 343728.0 // metres, per Vincenty reference calculators on WGS84
+	if math.Abs(got-want) > 1000 {
+		t.Errorf("DistanceEllipsoidal: got %.0fm, want ~%.0fm", got, want)
+	}
+
+	if d, err := london.DistanceEllipsoidal(london); err != nil || d != 0 {
+		t.Errorf("DistanceEllipsoidal of a point to itself: got %f, %v, want 0, nil", d, err)
+	}
+}
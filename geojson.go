@@ -0,0 +1,123 @@
+package geoc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type geoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+func pointCoordinates(p Point) []float64 {
+	return []float64{p.Lon.Value, p.Lat.Value}
+}
+
+func pointFromCoordinates(c []float64) (Point, error) {
+	if len(c) < 2 {
+		return Point{}, fmt.Errorf("geoc: GeoJSON coordinates need at least [lon, lat], got %v", c)
+	}
+	return newPoint(c[1], c[0]), nil
+}
+
+// MarshalGeoJSON encodes p as an RFC 7946 GeoJSON Point geometry, with
+// coordinates in [longitude, latitude] order.
+func MarshalGeoJSON(p Point) ([]byte, error) {
+	return json.Marshal(geoJSONGeometry{Type: "Point", Coordinates: pointCoordinates(p)})
+}
+
+// UnmarshalGeoJSON decodes an RFC 7946 GeoJSON Point geometry into a
+// Point. A third (altitude) coordinate, if present, is accepted but
+// discarded, since Point carries no altitude of its own.
+func UnmarshalGeoJSON(data []byte) (Point, error) {
+	var g struct {
+		Type        string    `json:"type"`
+		Coordinates []float64 `json:"coordinates"`
+	}
+	if err := json.Unmarshal(data, &g); err != nil {
+		return Point{}, fmt.Errorf("geoc: invalid GeoJSON: %v", err)
+	}
+	if g.Type != "Point" {
+		return Point{}, fmt.Errorf("geoc: expected GeoJSON type %q, got %q", "Point", g.Type)
+	}
+	return pointFromCoordinates(g.Coordinates)
+}
+
+// MarshalJSON implements json.Marshaler by encoding p as a GeoJSON
+// Point, so it drops straight into a user struct.
+func (p Point) MarshalJSON() ([]byte, error) {
+	return MarshalGeoJSON(p)
+}
+
+// UnmarshalJSON implements json.Unmarshaler by decoding p from a
+// GeoJSON Point.
+func (p *Point) UnmarshalJSON(data []byte) error {
+	np, err := UnmarshalGeoJSON(data)
+	if err != nil {
+		return err
+	}
+	*p = np
+	return nil
+}
+
+func marshalMultiCoordGeoJSON(typ string, pts []Point) ([]byte, error) {
+	coords := make([][]float64, len(pts))
+	for i, p := range pts {
+		coords[i] = pointCoordinates(p)
+	}
+	return json.Marshal(geoJSONGeometry{Type: typ, Coordinates: coords})
+}
+
+func unmarshalMultiCoordGeoJSON(typ string, data []byte) ([]Point, error) {
+	var g struct {
+		Type        string      `json:"type"`
+		Coordinates [][]float64 `json:"coordinates"`
+	}
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, fmt.Errorf("geoc: invalid GeoJSON: %v", err)
+	}
+	if g.Type != typ {
+		return nil, fmt.Errorf("geoc: expected GeoJSON type %q, got %q", typ, g.Type)
+	}
+
+	pts := make([]Point, len(g.Coordinates))
+	for i, c := range g.Coordinates {
+		p, err := pointFromCoordinates(c)
+		if err != nil {
+			return nil, err
+		}
+		pts[i] = p
+	}
+	return pts, nil
+}
+
+// MarshalGeoJSON encodes mp as an RFC 7946 GeoJSON MultiPoint geometry.
+func (mp MultiPoint) MarshalGeoJSON() ([]byte, error) {
+	return marshalMultiCoordGeoJSON("MultiPoint", mp)
+}
+
+// UnmarshalMultiPointGeoJSON decodes an RFC 7946 GeoJSON MultiPoint
+// geometry into a MultiPoint.
+func UnmarshalMultiPointGeoJSON(data []byte) (MultiPoint, error) {
+	pts, err := unmarshalMultiCoordGeoJSON("MultiPoint", data)
+	if err != nil {
+		return nil, err
+	}
+	return MultiPoint(pts), nil
+}
+
+// MarshalGeoJSON encodes ls as an RFC 7946 GeoJSON LineString geometry.
+func (ls LineString) MarshalGeoJSON() ([]byte, error) {
+	return marshalMultiCoordGeoJSON("LineString", ls)
+}
+
+// UnmarshalLineStringGeoJSON decodes an RFC 7946 GeoJSON LineString
+// geometry into a LineString.
+func UnmarshalLineStringGeoJSON(data []byte) (LineString, error) {
+	pts, err := unmarshalMultiCoordGeoJSON("LineString", data)
+	if err != nil {
+		return nil, err
+	}
+	return LineString(pts), nil
+}
@@ -0,0 +1,97 @@
+package geoc
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGeoJSONPointRoundTrip(t *testing.T) {
+	p := newPoint(52.52, 13.405)
+
+	data, err := MarshalGeoJSON(p)
+	if err != nil {
+		t.Fatalf("MarshalGeoJSON returned error: %v", err)
+	}
+	if want := `{"type":"Point","coordinates":[13.405,52.52]}`; string(data) != want {
+		t.Errorf("MarshalGeoJSON: got %s, want %s", data, want)
+	}
+
+	got, err := UnmarshalGeoJSON(data)
+	if err != nil {
+		t.Fatalf("UnmarshalGeoJSON returned error: %v", err)
+	}
+	if got != p {
+		t.Errorf("UnmarshalGeoJSON: got %+v, want %+v", got, p)
+	}
+}
+
+func TestGeoJSONPointWithAltitude(t *testing.T) {
+	got, err := UnmarshalGeoJSON([]byte(`{"type":"Point","coordinates":[13.405,52.52,34.5]}`))
+	if err != nil {
+		t.Fatalf("UnmarshalGeoJSON returned error: %v", err)
+	}
+	want := newPoint(52.52, 13.405)
+	if got != want {
+		t.Errorf("UnmarshalGeoJSON: got %+v, want %+v", got, want)
+	}
+}
+
+func TestPointJSONMarshaler(t *testing.T) {
+	type wrapper struct {
+		Location Point `json:"location"`
+	}
+
+	w := wrapper{Location: newPoint(52.52, 13.405)}
+	data, err := json.Marshal(w)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	var got wrapper
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if got.Location != w.Location {
+		t.Errorf("round-trip mismatch: got %+v, want %+v", got.Location, w.Location)
+	}
+}
+
+func TestMultiPointGeoJSONRoundTrip(t *testing.T) {
+	mp := MultiPoint{newPoint(52.52, 13.405), newPoint(48.8566, 2.3522)}
+
+	data, err := mp.MarshalGeoJSON()
+	if err != nil {
+		t.Fatalf("MarshalGeoJSON returned error: %v", err)
+	}
+
+	got, err := UnmarshalMultiPointGeoJSON(data)
+	if err != nil {
+		t.Fatalf("UnmarshalMultiPointGeoJSON returned error: %v", err)
+	}
+	if len(got) != len(mp) || got[0] != mp[0] || got[1] != mp[1] {
+		t.Errorf("round-trip mismatch: got %+v, want %+v", got, mp)
+	}
+}
+
+func TestLineStringGeoJSONRoundTrip(t *testing.T) {
+	ls := LineString{newPoint(52.52, 13.405), newPoint(48.8566, 2.3522)}
+
+	data, err := ls.MarshalGeoJSON()
+	if err != nil {
+		t.Fatalf("MarshalGeoJSON returned error: %v", err)
+	}
+
+	got, err := UnmarshalLineStringGeoJSON(data)
+	if err != nil {
+		t.Fatalf("UnmarshalLineStringGeoJSON returned error: %v", err)
+	}
+	if len(got) != len(ls) || got[0] != ls[0] || got[1] != ls[1] {
+		t.Errorf("round-trip mismatch: got %+v, want %+v", got, ls)
+	}
+}
+
+func TestUnmarshalGeoJSONWrongType(t *testing.T) {
+	if _, err := UnmarshalGeoJSON([]byte(`{"type":"LineString","coordinates":[[1,2],[3,4]]}`)); err == nil {
+		t.Error("expected error for mismatched GeoJSON type, got nil")
+	}
+}
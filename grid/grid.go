@@ -0,0 +1,384 @@
+// Package grid converts between geoc.Point and three grid systems:
+// UTM, MGRS (both on WGS84) and the amateur-radio Maidenhead locator.
+// It deliberately stops at ±84° latitude for UTM/MGRS; polar regions
+// are served by UPS, which this package does not yet implement.
+package grid
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/o-kos/geoc"
+)
+
+// WGS84 ellipsoid parameters and the Transverse Mercator scale factor
+// used by both UTM and MGRS.
+const (
+	semiMajorAxis = 6378137.0
+	flattening    = 1 / 298.257223563
+	k0            = 0.9996
+)
+
+var (
+	eccentricitySq       = flattening * (2 - flattening)
+	secondEccentricitySq = eccentricitySq / (1 - eccentricitySq)
+)
+
+func toRad(deg float64) float64 { return deg * math.Pi / 180 }
+func toDeg(rad float64) float64 { return rad * 180 / math.Pi }
+
+// Precision selects how finely ToMGRS and ToMaidenhead truncate their
+// output. FromUTM, FromMGRS and FromMaidenhead infer precision from
+// their input and ignore it.
+type Precision int
+
+// Supported precisions. For MGRS these are exact metre resolutions;
+// for Maidenhead they map to an approximate locator length tier (see
+// maidenheadLen).
+const (
+	Precision1m Precision = iota
+	Precision10m
+	Precision100m
+	Precision1000m
+	Precision10000m
+)
+
+// UTM is a Universal Transverse Mercator coordinate on the WGS84
+// ellipsoid.
+type UTM struct {
+	Zone       int
+	Hemisphere string // "N" or "S"
+	Easting    float64
+	Northing   float64
+}
+
+func zoneOf(lonDeg float64) int {
+	return int(math.Floor((lonDeg+180)/6)) + 1
+}
+
+// ToUTM projects p onto the Transverse Mercator grid for its UTM zone,
+// using k0 = 0.9996, false easting 500000m and, south of the equator,
+// false northing 10000000m. It refuses latitudes outside ±84°, where
+// UTM is not defined.
+func ToUTM(p geoc.Point) (UTM, error) {
+	lat, lon := p.Lat.Value, p.Lon.Value
+	if lat < -84 || lat > 84 {
+		return UTM{}, fmt.Errorf("grid: latitude %g out of UTM range (±84°)", lat)
+	}
+
+	zone := zoneOf(lon)
+	lonOrigin := toRad(float64((zone-1)*6 - 180 + 3))
+	latRad, lonRad := toRad(lat), toRad(lon)
+
+	sinLat, cosLat, tanLat := math.Sin(latRad), math.Cos(latRad), math.Tan(latRad)
+	n := semiMajorAxis / math.Sqrt(1-eccentricitySq*sinLat*sinLat)
+	t := tanLat * tanLat
+	c := secondEccentricitySq * cosLat * cosLat
+	a := cosLat * (lonRad - lonOrigin)
+	e2 := eccentricitySq
+
+	m := semiMajorAxis * ((1-e2/4-3*e2*e2/64-5*e2*e2*e2/256)*latRad -
+		(3*e2/8+3*e2*e2/32+45*e2*e2*e2/1024)*math.Sin(2*latRad) +
+		(15*e2*e2/256+45*e2*e2*e2/1024)*math.Sin(4*latRad) -
+		(35*e2*e2*e2/3072)*math.Sin(6*latRad))
+
+	easting := k0*n*(a+(1-t+c)*a*a*a/6+
+		(5-18*t+t*t+72*c-58*secondEccentricitySq)*a*a*a*a*a/120) + 500000.0
+
+	northing := k0 * (m + n*tanLat*(a*a/2+
+		(5-t+9*c+4*c*c)*a*a*a*a/24+
+		(61-58*t+t*t+600*c-330*secondEccentricitySq)*a*a*a*a*a*a/720))
+
+	hemisphere := "N"
+	if lat < 0 {
+		hemisphere = "S"
+		northing += 10000000.0
+	}
+
+	return UTM{Zone: zone, Hemisphere: hemisphere, Easting: easting, Northing: northing}, nil
+}
+
+// FromUTM converts a UTM coordinate back to a geoc.Point.
+func FromUTM(u UTM) (geoc.Point, error) {
+	if u.Zone < 1 || u.Zone > 60 {
+		return geoc.Point{}, fmt.Errorf("grid: UTM zone %d out of range (1..60)", u.Zone)
+	}
+	if u.Hemisphere != "N" && u.Hemisphere != "S" {
+		return geoc.Point{}, fmt.Errorf("grid: invalid UTM hemisphere %q", u.Hemisphere)
+	}
+
+	e2 := eccentricitySq
+	e1 := (1 - math.Sqrt(1-e2)) / (1 + math.Sqrt(1-e2))
+
+	x := u.Easting - 500000.0
+	y := u.Northing
+	if u.Hemisphere == "S" {
+		y -= 10000000.0
+	}
+	lonOrigin := float64((u.Zone-1)*6 - 180 + 3)
+
+	m := y / k0
+	mu := m / (semiMajorAxis * (1 - e2/4 - 3*e2*e2/64 - 5*e2*e2*e2/256))
+
+	phi1 := mu + (3*e1/2-27*e1*e1*e1/32)*math.Sin(2*mu) +
+		(21*e1*e1/16-55*e1*e1*e1*e1/32)*math.Sin(4*mu) +
+		(151*e1*e1*e1/96)*math.Sin(6*mu) +
+		(1097*e1*e1*e1*e1/512)*math.Sin(8*mu)
+
+	sinPhi1, cosPhi1, tanPhi1 := math.Sin(phi1), math.Cos(phi1), math.Tan(phi1)
+	n1 := semiMajorAxis / math.Sqrt(1-e2*sinPhi1*sinPhi1)
+	t1 := tanPhi1 * tanPhi1
+	c1 := secondEccentricitySq * cosPhi1 * cosPhi1
+	r1 := semiMajorAxis * (1 - e2) / math.Pow(1-e2*sinPhi1*sinPhi1, 1.5)
+	d := x / (n1 * k0)
+
+	lat := phi1 - (n1*tanPhi1/r1)*(d*d/2-
+		(5+3*t1+10*c1-4*c1*c1-9*secondEccentricitySq)*d*d*d*d/24+
+		(61+90*t1+298*c1+45*t1*t1-252*secondEccentricitySq-3*c1*c1)*d*d*d*d*d*d/720)
+
+	lon := (d - (1+2*t1+c1)*d*d*d/6 +
+		(5-2*c1+28*t1-3*c1*c1+8*secondEccentricitySq+24*t1*t1)*d*d*d*d*d/120) / cosPhi1
+
+	return geoc.Point{
+		Lat: geoc.Coord{Value: toDeg(lat), Loc: geoc.Lat},
+		Lon: geoc.Coord{Value: lonOrigin + toDeg(lon), Loc: geoc.Lon},
+	}, nil
+}
+
+// latBands lists the 20 MGRS latitude band letters from -80° to 84°,
+// each spanning 8° except the last (X), which spans 12° to cover the
+// UTM latitude limit.
+const latBands = "CDEFGHJKLMNPQRSTUVWX"
+
+func latBand(lat float64) (byte, error) {
+	if lat < -80 || lat > 84 {
+		return 0, fmt.Errorf("grid: latitude %g out of MGRS range (-80°..84°)", lat)
+	}
+	idx := int((lat + 80) / 8)
+	if idx > 19 {
+		idx = 19
+	}
+	return latBands[idx], nil
+}
+
+// e100kLetters and n100kLetters are the NGA 100km square ID alphabets
+// (A-Z excluding I and O), cycling every 3 zones for columns and every
+// 2 zones for rows so adjacent zones don't repeat a square ID nearby.
+var (
+	e100kLetters = [3]string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}
+	n100kLetters = [2]string{"ABCDEFGHJKLMNPQRSTUV", "FGHJKLMNPQRSTUVABCDE"}
+)
+
+func set100k(zone int) int {
+	set := zone % 6
+	if set == 0 {
+		set = 6
+	}
+	return set
+}
+
+func square100kID(easting, northing float64, zone int) string {
+	set := set100k(zone) - 1
+	col := int(easting/100000) - 1
+	row := int(northing/100000) % 20
+
+	return string([]byte{e100kLetters[set%3][col], n100kLetters[set%2][row]})
+}
+
+func precisionDigits(p Precision) int {
+	switch p {
+	case Precision10m:
+		return 4
+	case Precision100m:
+		return 3
+	case Precision1000m:
+		return 2
+	case Precision10000m:
+		return 1
+	default:
+		return 5
+	}
+}
+
+// ToMGRS re-encodes p's UTM projection as an MGRS string: zone number,
+// latitude band letter, two-letter 100km grid square, then easting and
+// northing truncated to the resolution named by precision (5 digits
+// each for Precision1m down to 1 digit each for Precision10000m).
+func ToMGRS(p geoc.Point, precision Precision) (string, error) {
+	u, err := ToUTM(p)
+	if err != nil {
+		return "", err
+	}
+	band, err := latBand(p.Lat.Value)
+	if err != nil {
+		return "", err
+	}
+	square := square100kID(u.Easting, u.Northing, u.Zone)
+
+	digits := precisionDigits(precision)
+	divisor := math.Pow10(5 - digits)
+	easting := int(math.Mod(u.Easting, 100000) / divisor)
+	northing := int(math.Mod(u.Northing, 100000) / divisor)
+
+	return fmt.Sprintf("%d%c%s%0*d%0*d", u.Zone, band, square, digits, easting, digits, northing), nil
+}
+
+var mgrsRegExp = regexp.MustCompile(`(?i)^(\d{1,2})([C-HJ-NP-X])([A-Z]{2})(\d+)$`)
+
+// FromMGRS parses an MGRS string back to a geoc.Point. Because the
+// 100km square row letter only encodes northing modulo 2,000,000m, it
+// resolves the ambiguity by picking the 2,000,000m block whose
+// decoded latitude falls inside the string's own latitude band.
+func FromMGRS(s string) (geoc.Point, error) {
+	m := mgrsRegExp.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if m == nil {
+		return geoc.Point{}, fmt.Errorf("grid: invalid MGRS string %q", s)
+	}
+
+	zone, _ := strconv.Atoi(m[1])
+	band := m[2][0]
+	square := m[3]
+	digits := m[4]
+	if len(digits)%2 != 0 {
+		return geoc.Point{}, fmt.Errorf("grid: MGRS numeric part %q must have an even number of digits", digits)
+	}
+
+	half := len(digits) / 2
+	scale := math.Pow10(5 - half)
+	eastingDigits, _ := strconv.Atoi(digits[:half])
+	northingDigits, _ := strconv.Atoi(digits[half:])
+
+	set := set100k(zone) - 1
+	col := strings.IndexByte(e100kLetters[set%3], square[0])
+	if col == -1 {
+		return geoc.Point{}, fmt.Errorf("grid: invalid MGRS 100km column letter %q", string(square[0]))
+	}
+	row := strings.IndexByte(n100kLetters[set%2], square[1])
+	if row == -1 {
+		return geoc.Point{}, fmt.Errorf("grid: invalid MGRS 100km row letter %q", string(square[1]))
+	}
+
+	easting := float64(col+1)*100000 + float64(eastingDigits)*scale
+	baseNorthing := float64(row)*100000 + float64(northingDigits)*scale
+
+	bandIdx := strings.IndexByte(latBands, band)
+	minLat := float64(bandIdx)*8 - 80
+	maxLat := minLat + 8
+	if band == 'X' {
+		maxLat = 84
+	}
+
+	hemisphere := "N"
+	if band < 'N' {
+		hemisphere = "S"
+	}
+
+	for k := 0; k < 5; k++ {
+		northing := baseNorthing + float64(k)*2000000
+		p, err := FromUTM(UTM{Zone: zone, Hemisphere: hemisphere, Easting: easting, Northing: northing})
+		if err == nil && p.Lat.Value >= minLat-0.001 && p.Lat.Value < maxLat+0.001 {
+			return p, nil
+		}
+	}
+
+	return geoc.Point{}, fmt.Errorf("grid: could not resolve MGRS northing for %q", s)
+}
+
+// maidenheadLen maps a Precision to a Maidenhead locator length: 4
+// characters (field + square, ~2° x 1°) for the coarsest precisions,
+// up to 8 characters (adding subsquare letters and an extended digit
+// pair, ~30" x 15") for the finest. Maidenhead has no notion of metre
+// resolution, so this is an approximate tier mapping rather than an
+// exact equivalence.
+func maidenheadLen(p Precision) int {
+	switch p {
+	case Precision10000m:
+		return 4
+	case Precision1000m, Precision100m:
+		return 6
+	default:
+		return 8
+	}
+}
+
+// ToMaidenhead renders p as an amateur-radio Maidenhead locator, e.g.
+// "JO01", "JO01bm" or "JO01bm34", truncated to the length named by
+// precision.
+func ToMaidenhead(p geoc.Point, precision Precision) (string, error) {
+	lat, lon := p.Lat.Value, p.Lon.Value
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return "", fmt.Errorf("grid: point %v out of range", p)
+	}
+
+	lon += 180
+	lat += 90
+
+	fieldLon, fieldLat := int(lon/20), int(lat/10)
+	lon -= float64(fieldLon) * 20
+	lat -= float64(fieldLat) * 10
+
+	squareLon, squareLat := int(lon/2), int(lat)
+	lon -= float64(squareLon) * 2
+	lat -= float64(squareLat)
+
+	lon *= 12
+	lat *= 24
+	subLon, subLat := int(lon), int(lat)
+	lon -= float64(subLon)
+	lat -= float64(subLat)
+
+	extLon, extLat := int(lon*10), int(lat*10)
+
+	locator := fmt.Sprintf("%c%c%d%d%c%c%d%d",
+		'A'+fieldLon, 'A'+fieldLat, squareLon, squareLat,
+		'a'+subLon, 'a'+subLat, extLon, extLat)
+
+	return locator[:maidenheadLen(precision)], nil
+}
+
+var maidenheadRegExp = regexp.MustCompile(`(?i)^([A-R]{2})(\d{2})?([A-X]{2})?(\d{2})?$`)
+
+// FromMaidenhead parses a Maidenhead locator of 2, 4, 6 or 8
+// characters back to a geoc.Point at the centre of the smallest cell
+// the locator resolves.
+func FromMaidenhead(s string) (geoc.Point, error) {
+	m := maidenheadRegExp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil || m[1] == "" {
+		return geoc.Point{}, fmt.Errorf("grid: invalid Maidenhead locator %q", s)
+	}
+
+	field := strings.ToUpper(m[1])
+	lon := float64(field[0]-'A')*20 - 180
+	lat := float64(field[1]-'A')*10 - 90
+	lonSize, latSize := 20.0, 10.0
+
+	if m[2] != "" {
+		sq, _ := strconv.Atoi(m[2])
+		lon += float64(sq/10) * 2
+		lat += float64(sq % 10)
+		lonSize, latSize = 2, 1
+	}
+	if m[3] != "" {
+		sub := strings.ToLower(m[3])
+		lon += float64(sub[0]-'a') * (lonSize / 24)
+		lat += float64(sub[1]-'a') * (latSize / 24)
+		lonSize, latSize = lonSize/24, latSize/24
+	}
+	if m[4] != "" {
+		ext, _ := strconv.Atoi(m[4])
+		lon += float64(ext/10) * (lonSize / 10)
+		lat += float64(ext%10) * (latSize / 10)
+		lonSize, latSize = lonSize/10, latSize/10
+	}
+
+	lon += lonSize / 2
+	lat += latSize / 2
+
+	return geoc.Point{
+		Lat: geoc.Coord{Value: lat, Loc: geoc.Lat},
+		Lon: geoc.Coord{Value: lon, Loc: geoc.Lon},
+	}, nil
+}
@@ -0,0 +1,138 @@
+package grid
+
+import (
+	"math"
+	"testing"
+
+	"github.com/o-kos/geoc"
+)
+
+func almostEqual(a, b, eps float64) bool {
+	return math.Abs(a-b) <= eps
+}
+
+func point(lat, lon float64) geoc.Point {
+	return geoc.Point{Lat: geoc.Coord{Value: lat, Loc: geoc.Lat}, Lon: geoc.Coord{Value: lon, Loc: geoc.Lon}}
+}
+
+func TestUTMRoundTrip(t *testing.T) {
+	london := point(51.5074, -0.1278)
+
+	u, err := ToUTM(london)
+	if err != nil {
+		t.Fatalf("ToUTM returned error: %v", err)
+	}
+	if u.Zone != 30 || u.Hemisphere != "N" {
+		t.Errorf("ToUTM: got zone %d hemisphere %s, want 30 N", u.Zone, u.Hemisphere)
+	}
+
+	got, err := FromUTM(u)
+	if err != nil {
+		t.Fatalf("FromUTM returned error: %v", err)
+	}
+	if !almostEqual(got.Lat.Value, london.Lat.Value, 0.0001) || !almostEqual(got.Lon.Value, london.Lon.Value, 0.0001) {
+		t.Errorf("round-trip mismatch: got %+v, want %+v", got, london)
+	}
+}
+
+func TestToUTMRejectsPolarLatitude(t *testing.T) {
+	if _, err := ToUTM(point(85, 0)); err == nil {
+		t.Error("expected error for latitude outside ±84°, got nil")
+	}
+}
+
+func TestFromUTMRejectsBadZone(t *testing.T) {
+	if _, err := FromUTM(UTM{Zone: 61, Hemisphere: "N", Easting: 500000, Northing: 0}); err == nil {
+		t.Error("expected error for zone out of range, got nil")
+	}
+}
+
+func TestMGRSRoundTrip(t *testing.T) {
+	cases := []geoc.Point{
+		point(51.5074, -0.1278),   // London, northern hemisphere
+		point(-33.8688, 151.2093), // Sydney, southern hemisphere
+	}
+
+	for _, p := range cases {
+		s, err := ToMGRS(p, Precision1m)
+		if err != nil {
+			t.Fatalf("ToMGRS(%v) returned error: %v", p, err)
+		}
+
+		got, err := FromMGRS(s)
+		if err != nil {
+			t.Fatalf("FromMGRS(%q) returned error: %v", s, err)
+		}
+		if !almostEqual(got.Lat.Value, p.Lat.Value, 0.001) || !almostEqual(got.Lon.Value, p.Lon.Value, 0.001) {
+			t.Errorf("round-trip mismatch for %q: got %+v, want %+v", s, got, p)
+		}
+	}
+}
+
+func TestToMGRSPrecision(t *testing.T) {
+	p := point(51.5074, -0.1278)
+
+	got, err := ToMGRS(p, Precision10000m)
+	if err != nil {
+		t.Fatalf("ToMGRS returned error: %v", err)
+	}
+	if want := "30UXC91"; got != want {
+		t.Errorf("ToMGRS at Precision10000m: got %q, want %q", got, want)
+	}
+}
+
+func TestFromMGRSInvalid(t *testing.T) {
+	if _, err := FromMGRS("not an mgrs string"); err == nil {
+		t.Error("expected error for malformed MGRS string, got nil")
+	}
+}
+
+func TestMaidenheadRoundTrip(t *testing.T) {
+	london := point(51.5074, -0.1278)
+
+	s, err := ToMaidenhead(london, Precision1m)
+	if err != nil {
+		t.Fatalf("ToMaidenhead returned error: %v", err)
+	}
+	if want := "IO91wm"; s[:6] != want {
+		t.Errorf("ToMaidenhead: got %q, want prefix %q", s, want)
+	}
+
+	got, err := FromMaidenhead(s)
+	if err != nil {
+		t.Fatalf("FromMaidenhead(%q) returned error: %v", s, err)
+	}
+	if d := got.DistanceTo(london); d > 1000 {
+		t.Errorf("round-trip landed %.0fm away from expected point", d)
+	}
+}
+
+func TestToMaidenheadPrecisionLengths(t *testing.T) {
+	p := point(51.5074, -0.1278)
+
+	cases := []struct {
+		precision Precision
+		length    int
+	}{
+		{Precision10000m, 4},
+		{Precision1000m, 6},
+		{Precision100m, 6},
+		{Precision10m, 8},
+		{Precision1m, 8},
+	}
+	for _, c := range cases {
+		s, err := ToMaidenhead(p, c.precision)
+		if err != nil {
+			t.Fatalf("ToMaidenhead returned error: %v", err)
+		}
+		if len(s) != c.length {
+			t.Errorf("ToMaidenhead at precision %v: got length %d (%q), want %d", c.precision, len(s), s, c.length)
+		}
+	}
+}
+
+func TestFromMaidenheadInvalid(t *testing.T) {
+	if _, err := FromMaidenhead("1234"); err == nil {
+		t.Error("expected error for malformed Maidenhead locator, got nil")
+	}
+}
@@ -0,0 +1,241 @@
+// Package loc converts between geoc.Point values and the DNS LOC record
+// wire format described in RFC 1876, along with its textual zone-file
+// presentation (e.g. "51 30 12.748 N 00 07 39.611 W 0.00m 1m 10000m 10m").
+package loc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/o-kos/geoc"
+)
+
+const (
+	version = 0
+
+	// the equator/prime meridian are encoded as 1<<31; latitudes and
+	// longitudes are offsets from it in thousandths of an arc-second.
+	zeroPoint = 1 << 31
+
+	// altitude is stored as centimetres above a -100000.00m reference,
+	// per RFC 1876 section 3.
+	altBase = 100000.0
+
+	minSize      = 0.0
+	maxSize      = 90000000.0
+	minAltitude  = -100000.0
+	maxAltitude  = 42849672.95
+	rdataLength  = 16
+	arcSecondsMs = 3600 * 1000.0 // thousandths of an arc-second per degree
+)
+
+var pow10 = [...]int64{1, 10, 100, 1000, 10000, 100000, 1000000, 10000000, 100000000, 1000000000}
+
+// MarshalLOC encodes p, alt, size, hp and vp (altitude, size and
+// horizontal/vertical precision, all in metres) into the 16-byte RDATA
+// of a DNS LOC record.
+func MarshalLOC(p geoc.Point, alt, size, hp, vp float64) ([]byte, error) {
+	if p.Lat.Value < -90 || p.Lat.Value > 90 {
+		return nil, fmt.Errorf("loc: latitude %g out of range", p.Lat.Value)
+	}
+	if p.Lon.Value < -180 || p.Lon.Value > 180 {
+		return nil, fmt.Errorf("loc: longitude %g out of range", p.Lon.Value)
+	}
+	if alt < minAltitude || alt > maxAltitude {
+		return nil, fmt.Errorf("loc: altitude %g out of range", alt)
+	}
+
+	sizeByte, err := encodePrecision(size)
+	if err != nil {
+		return nil, fmt.Errorf("loc: size: %v", err)
+	}
+	hpByte, err := encodePrecision(hp)
+	if err != nil {
+		return nil, fmt.Errorf("loc: horizontal precision: %v", err)
+	}
+	vpByte, err := encodePrecision(vp)
+	if err != nil {
+		return nil, fmt.Errorf("loc: vertical precision: %v", err)
+	}
+
+	buf := make([]byte, rdataLength)
+	buf[0] = version
+	buf[1] = sizeByte
+	buf[2] = hpByte
+	buf[3] = vpByte
+	binary.BigEndian.PutUint32(buf[4:8], degreesToWire(p.Lat.Value))
+	binary.BigEndian.PutUint32(buf[8:12], degreesToWire(p.Lon.Value))
+	binary.BigEndian.PutUint32(buf[12:16], uint32(math.Round((alt+altBase)*100)))
+
+	return buf, nil
+}
+
+// UnmarshalLOC decodes the 16-byte RDATA of a DNS LOC record, returning
+// the location as a geoc.Point together with altitude, size and
+// horizontal/vertical precision, all in metres.
+func UnmarshalLOC(rdata []byte) (p geoc.Point, alt, size, hp, vp float64, err error) {
+	if len(rdata) != rdataLength {
+		return geoc.Point{}, 0, 0, 0, 0, fmt.Errorf("loc: RDATA must be %d bytes, got %d", rdataLength, len(rdata))
+	}
+	if rdata[0] != version {
+		return geoc.Point{}, 0, 0, 0, 0, fmt.Errorf("loc: unsupported LOC version %d", rdata[0])
+	}
+
+	size = decodePrecision(rdata[1])
+	hp = decodePrecision(rdata[2])
+	vp = decodePrecision(rdata[3])
+
+	lat := wireToDegrees(binary.BigEndian.Uint32(rdata[4:8]))
+	lon := wireToDegrees(binary.BigEndian.Uint32(rdata[8:12]))
+	alt = float64(binary.BigEndian.Uint32(rdata[12:16]))/100 - altBase
+
+	return geoc.Point{Lat: geoc.Coord{Value: lat, Loc: geoc.Lat}, Lon: geoc.Coord{Value: lon, Loc: geoc.Lon}}, alt, size, hp, vp, nil
+}
+
+// degreesToWire converts a latitude or longitude in degrees to the
+// uint32 wire representation: an offset in thousandths of an
+// arc-second from the equator/prime meridian, with 1<<31 as zero.
+func degreesToWire(deg float64) uint32 {
+	return uint32(int64(zeroPoint) + int64(math.Round(deg*arcSecondsMs)))
+}
+
+func wireToDegrees(v uint32) float64 {
+	return float64(int64(v)-zeroPoint) / arcSecondsMs
+}
+
+// encodePrecision packs a metre value into the RFC 1876 "base * 10^exponent
+// centimetres" nibble pair used for SIZE, HORIZ PRE and VERT PRE.
+func encodePrecision(meters float64) (byte, error) {
+	if meters < minSize || meters > maxSize {
+		return 0, fmt.Errorf("%gm out of range", meters)
+	}
+
+	cm := int64(math.Round(meters * 100))
+	exponent := 0
+	for exponent < 9 && cm >= pow10[exponent+1] {
+		exponent++
+	}
+	mantissa := cm / pow10[exponent]
+	if mantissa > 9 {
+		mantissa = 9
+	}
+
+	return byte(mantissa<<4) | byte(exponent), nil
+}
+
+func decodePrecision(b byte) float64 {
+	mantissa := int64(b >> 4)
+	exponent := int64(b & 0x0f)
+	return float64(mantissa*pow10[exponent]) / 100
+}
+
+// FormatLOCString renders p, alt, size, hp and vp as the textual
+// zone-file form of a LOC record, e.g.
+// "51 30 12.748 N 00 07 39.611 W 0.00m 1m 10000m 10m".
+func FormatLOCString(p geoc.Point, alt, size, hp, vp float64) (string, error) {
+	if p.Lat.Value < -90 || p.Lat.Value > 90 {
+		return "", fmt.Errorf("loc: latitude %g out of range", p.Lat.Value)
+	}
+	if p.Lon.Value < -180 || p.Lon.Value > 180 {
+		return "", fmt.Errorf("loc: longitude %g out of range", p.Lon.Value)
+	}
+
+	latStr := formatDMS(p.Lat.Value, "N", "S")
+	lonStr := formatDMS(p.Lon.Value, "E", "W")
+
+	return fmt.Sprintf(
+		"%s %s %s %s %s %s",
+		latStr, lonStr, formatAltitude(alt), formatPrecision(size), formatPrecision(hp), formatPrecision(vp),
+	), nil
+}
+
+// ParseLOCString parses the textual zone-file form of a LOC record back
+// into a geoc.Point plus altitude, size and horizontal/vertical
+// precision, reusing geoc.ParseCoord for the "DD MM SS.sss N" syntax of
+// each half of the coordinate pair. Per RFC 1876's grammar, altitude is
+// mandatory; trailing size/precision fields may be omitted, in which
+// case the RFC 1876 defaults (1m/10000m/10m) apply.
+func ParseLOCString(s string) (p geoc.Point, alt, size, hp, vp float64, err error) {
+	fields := strings.Fields(s)
+
+	latEnd := indexOfHemisphere(fields, "N", "S")
+	if latEnd == -1 {
+		return geoc.Point{}, 0, 0, 0, 0, fmt.Errorf("loc: missing N/S latitude hemisphere in %q", s)
+	}
+	lonEnd := indexOfHemisphere(fields[latEnd+1:], "E", "W")
+	if lonEnd == -1 {
+		return geoc.Point{}, 0, 0, 0, 0, fmt.Errorf("loc: missing E/W longitude hemisphere in %q", s)
+	}
+	lonEnd += latEnd + 1
+
+	lat, err := geoc.ParseCoord(strings.Join(fields[:latEnd+1], " "))
+	if err != nil {
+		return geoc.Point{}, 0, 0, 0, 0, fmt.Errorf("loc: latitude: %v", err)
+	}
+	lon, err := geoc.ParseCoord(strings.Join(fields[latEnd+1:lonEnd+1], " "))
+	if err != nil {
+		return geoc.Point{}, 0, 0, 0, 0, fmt.Errorf("loc: longitude: %v", err)
+	}
+
+	rest := fields[lonEnd+1:]
+	if len(rest) == 0 {
+		return geoc.Point{}, 0, 0, 0, 0, fmt.Errorf("loc: missing altitude in %q", s)
+	}
+
+	altitude, err := strconv.ParseFloat(strings.TrimSuffix(rest[0], "m"), 64)
+	if err != nil {
+		return geoc.Point{}, 0, 0, 0, 0, fmt.Errorf("loc: invalid value %q in %q", rest[0], s)
+	}
+
+	rest = rest[1:]
+	defaults := []float64{1, 10000, 10}
+	values := make([]float64, len(defaults))
+	copy(values, defaults)
+	for i := range rest {
+		if i >= len(values) {
+			return geoc.Point{}, 0, 0, 0, 0, fmt.Errorf("loc: too many fields in %q", s)
+		}
+		values[i], err = strconv.ParseFloat(strings.TrimSuffix(rest[i], "m"), 64)
+		if err != nil {
+			return geoc.Point{}, 0, 0, 0, 0, fmt.Errorf("loc: invalid value %q in %q", rest[i], s)
+		}
+	}
+
+	return geoc.Point{Lat: lat, Lon: lon}, altitude, values[0], values[1], values[2], nil
+}
+
+func indexOfHemisphere(fields []string, pos, neg string) int {
+	for i, f := range fields {
+		if f == pos || f == neg {
+			return i
+		}
+	}
+	return -1
+}
+
+func formatDMS(deg float64, pos, neg string) string {
+	letter := pos
+	if deg < 0 {
+		letter = neg
+	}
+	v := math.Abs(deg)
+	d := math.Trunc(v)
+	m := math.Trunc((v - d) * 60)
+	s := ((v-d)*60 - m) * 60
+
+	return fmt.Sprintf("%02d %02d %06.3f %s", int(d), int(m), s, letter)
+}
+
+func formatAltitude(v float64) string {
+	return fmt.Sprintf("%.2fm", v)
+}
+
+func formatPrecision(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimRight(s, ".")
+	return s + "m"
+}
@@ -0,0 +1,95 @@
+package loc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/o-kos/geoc"
+)
+
+func almostEqual(a, b, eps float64) bool {
+	return math.Abs(a-b) <= eps
+}
+
+func TestMarshalUnmarshalLOC(t *testing.T) {
+	p := geoc.Point{Lat: geoc.Coord{Value: 51.503541, Loc: geoc.Lat}, Lon: geoc.Coord{Value: -0.127675, Loc: geoc.Lon}}
+
+	rdata, err := MarshalLOC(p, 0, 1, 10000, 10)
+	if err != nil {
+		t.Fatalf("MarshalLOC returned error: %v", err)
+	}
+	if len(rdata) != rdataLength {
+		t.Fatalf("expected %d bytes of RDATA, got %d", rdataLength, len(rdata))
+	}
+
+	got, alt, size, hp, vp, err := UnmarshalLOC(rdata)
+	if err != nil {
+		t.Fatalf("UnmarshalLOC returned error: %v", err)
+	}
+	if !almostEqual(got.Lat.Value, p.Lat.Value, 0.0000003) || !almostEqual(got.Lon.Value, p.Lon.Value, 0.0000003) {
+		t.Errorf("round-trip point mismatch: got %+v, want %+v", got, p)
+	}
+	if alt != 0 || size != 1 || hp != 10000 || vp != 10 {
+		t.Errorf("round-trip params mismatch: alt=%g size=%g hp=%g vp=%g", alt, size, hp, vp)
+	}
+}
+
+func TestUnmarshalLOCInvalidLength(t *testing.T) {
+	if _, _, _, _, _, err := UnmarshalLOC([]byte{0, 1, 2}); err == nil {
+		t.Error("expected error for short RDATA, got nil")
+	}
+}
+
+func TestMarshalLOCOutOfRange(t *testing.T) {
+	if _, err := MarshalLOC(geoc.Point{Lat: geoc.Coord{Value: 91, Loc: geoc.Lat}, Lon: geoc.Coord{Value: 0, Loc: geoc.Lon}}, 0, 1, 10000, 10); err == nil {
+		t.Error("expected error for out-of-range latitude, got nil")
+	}
+	if _, err := MarshalLOC(geoc.Point{Lat: geoc.Coord{Value: 0, Loc: geoc.Lat}, Lon: geoc.Coord{Value: 0, Loc: geoc.Lon}}, 0, 100000000, 10000, 10); err == nil {
+		t.Error("expected error for out-of-range size, got nil")
+	}
+}
+
+func TestFormatParseLOCString(t *testing.T) {
+	p := geoc.Point{Lat: geoc.Coord{Value: 51.503541, Loc: geoc.Lat}, Lon: geoc.Coord{Value: -0.127675, Loc: geoc.Lon}}
+
+	s, err := FormatLOCString(p, 0, 1, 10000, 10)
+	if err != nil {
+		t.Fatalf("FormatLOCString returned error: %v", err)
+	}
+
+	got, alt, size, hp, vp, err := ParseLOCString(s)
+	if err != nil {
+		t.Fatalf("ParseLOCString(%q) returned error: %v", s, err)
+	}
+	if !almostEqual(got.Lat.Value, p.Lat.Value, 0.0001) || !almostEqual(got.Lon.Value, p.Lon.Value, 0.0001) {
+		t.Errorf("round-trip point mismatch: got %+v, want %+v", got, p)
+	}
+	if alt != 0 || size != 1 || hp != 10000 || vp != 10 {
+		t.Errorf("round-trip params mismatch: alt=%g size=%g hp=%g vp=%g", alt, size, hp, vp)
+	}
+}
+
+func TestParseLOCStringDefaults(t *testing.T) {
+	p, alt, size, hp, vp, err := ParseLOCString("51 30 12.748 N 00 07 39.611 W 0m")
+	if err != nil {
+		t.Fatalf("ParseLOCString returned error: %v", err)
+	}
+	if !almostEqual(p.Lat.Value, 51.503541, 0.0001) || !almostEqual(p.Lon.Value, -0.127669, 0.0001) {
+		t.Errorf("unexpected point: %+v", p)
+	}
+	if alt != 0 || size != 1 || hp != 10000 || vp != 10 {
+		t.Errorf("unexpected defaults: alt=%g size=%g hp=%g vp=%g", alt, size, hp, vp)
+	}
+}
+
+func TestParseLOCStringInvalid(t *testing.T) {
+	if _, _, _, _, _, err := ParseLOCString("not a loc record"); err == nil {
+		t.Error("expected error for malformed LOC string, got nil")
+	}
+}
+
+func TestParseLOCStringMissingAltitude(t *testing.T) {
+	if _, _, _, _, _, err := ParseLOCString("51 30 12.748 N 00 07 39.611 W"); err == nil {
+		t.Error("expected error for LOC string missing mandatory altitude, got nil")
+	}
+}
@@ -0,0 +1,9 @@
+package geoc
+
+// MultiPoint is an ordered collection of points sharing Point's
+// GeoJSON and WKT codecs.
+type MultiPoint []Point
+
+// LineString is an ordered sequence of points forming a path, sharing
+// Point's GeoJSON and WKT codecs.
+type LineString []Point
@@ -0,0 +1,117 @@
+package geoc
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	wktPointRegExp = regexp.MustCompile(`(?i)^POINT\s*(?:Z\s*)?\(\s*([-\d.]+)\s+([-\d.]+)(?:\s+[-\d.]+)?\s*\)$`)
+	wktMultiRegExp = regexp.MustCompile(`(?i)^(MULTIPOINT|LINESTRING)\s*\(\s*(.*)\s*\)$`)
+)
+
+func formatWKTNumber(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func formatWKTCoord(p Point) string {
+	return formatWKTNumber(p.Lon.Value) + " " + formatWKTNumber(p.Lat.Value)
+}
+
+// MarshalWKT encodes p as a WKT POINT, e.g. "POINT(13.405 52.52)".
+func MarshalWKT(p Point) string {
+	return fmt.Sprintf("POINT(%s)", formatWKTCoord(p))
+}
+
+// ParseWKT parses a WKT "POINT(lon lat)" or "POINT Z (lon lat alt)"
+// string, with case-insensitive keywords, into a Point. A Z altitude,
+// if present, is accepted but discarded, since Point carries no
+// altitude of its own.
+func ParseWKT(s string) (Point, error) {
+	m := wktPointRegExp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Point{}, fmt.Errorf("geoc: invalid WKT point %q", s)
+	}
+
+	lon, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("geoc: invalid WKT longitude %q", m[1])
+	}
+	lat, err := strconv.ParseFloat(m[2], 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("geoc: invalid WKT latitude %q", m[2])
+	}
+
+	return newPoint(lat, lon), nil
+}
+
+func parseWKTCoordList(s string) ([]Point, error) {
+	parts := strings.Split(s, ",")
+	pts := make([]Point, len(parts))
+	for i, part := range parts {
+		fields := strings.Fields(strings.TrimSpace(part))
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("geoc: invalid WKT coordinate %q", part)
+		}
+		lon, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("geoc: invalid WKT longitude %q", fields[0])
+		}
+		lat, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("geoc: invalid WKT latitude %q", fields[1])
+		}
+		pts[i] = newPoint(lat, lon)
+	}
+	return pts, nil
+}
+
+// MarshalWKT encodes mp as a WKT MULTIPOINT, e.g.
+// "MULTIPOINT(13.405 52.52, 2.3522 48.8566)".
+func (mp MultiPoint) MarshalWKT() string {
+	parts := make([]string, len(mp))
+	for i, p := range mp {
+		parts[i] = formatWKTCoord(p)
+	}
+	return fmt.Sprintf("MULTIPOINT(%s)", strings.Join(parts, ", "))
+}
+
+// ParseMultiPointWKT parses a WKT "MULTIPOINT(lon lat, ...)" string,
+// with case-insensitive keyword, into a MultiPoint.
+func ParseMultiPointWKT(s string) (MultiPoint, error) {
+	pts, err := parseWKTGeometry(s, "MULTIPOINT")
+	if err != nil {
+		return nil, err
+	}
+	return MultiPoint(pts), nil
+}
+
+// MarshalWKT encodes ls as a WKT LINESTRING, e.g.
+// "LINESTRING(13.405 52.52, 2.3522 48.8566)".
+func (ls LineString) MarshalWKT() string {
+	parts := make([]string, len(ls))
+	for i, p := range ls {
+		parts[i] = formatWKTCoord(p)
+	}
+	return fmt.Sprintf("LINESTRING(%s)", strings.Join(parts, ", "))
+}
+
+// ParseLineStringWKT parses a WKT "LINESTRING(lon lat, ...)" string,
+// with case-insensitive keyword, into a LineString.
+func ParseLineStringWKT(s string) (LineString, error) {
+	pts, err := parseWKTGeometry(s, "LINESTRING")
+	if err != nil {
+		return nil, err
+	}
+	return LineString(pts), nil
+}
+
+func parseWKTGeometry(s, typ string) ([]Point, error) {
+	m := wktMultiRegExp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil || !strings.EqualFold(m[1], typ) {
+		return nil, fmt.Errorf("geoc: invalid WKT %s %q", strings.ToLower(typ), s)
+	}
+	return parseWKTCoordList(m[2])
+}
@@ -0,0 +1,69 @@
+package geoc
+
+import "testing"
+
+func TestWKTPointRoundTrip(t *testing.T) {
+	p := newPoint(52.52, 13.405)
+
+	s := MarshalWKT(p)
+	if want := "POINT(13.405 52.52)"; s != want {
+		t.Errorf("MarshalWKT: got %q, want %q", s, want)
+	}
+
+	got, err := ParseWKT(s)
+	if err != nil {
+		t.Fatalf("ParseWKT(%q) returned error: %v", s, err)
+	}
+	if got != p {
+		t.Errorf("ParseWKT: got %+v, want %+v", got, p)
+	}
+}
+
+func TestParseWKTPointZ(t *testing.T) {
+	got, err := ParseWKT("POINT Z (13.405 52.52 34.5)")
+	if err != nil {
+		t.Fatalf("ParseWKT returned error: %v", err)
+	}
+	want := newPoint(52.52, 13.405)
+	if got != want {
+		t.Errorf("ParseWKT: got %+v, want %+v", got, want)
+	}
+}
+
+func TestParseWKTCaseInsensitive(t *testing.T) {
+	if _, err := ParseWKT("point(13.405 52.52)"); err != nil {
+		t.Errorf("ParseWKT with lowercase keyword returned error: %v", err)
+	}
+}
+
+func TestParseWKTInvalid(t *testing.T) {
+	if _, err := ParseWKT("not a wkt point"); err == nil {
+		t.Error("expected error for malformed WKT, got nil")
+	}
+}
+
+func TestMultiPointWKTRoundTrip(t *testing.T) {
+	mp := MultiPoint{newPoint(52.52, 13.405), newPoint(48.8566, 2.3522)}
+
+	s := mp.MarshalWKT()
+	got, err := ParseMultiPointWKT(s)
+	if err != nil {
+		t.Fatalf("ParseMultiPointWKT(%q) returned error: %v", s, err)
+	}
+	if len(got) != len(mp) || got[0] != mp[0] || got[1] != mp[1] {
+		t.Errorf("round-trip mismatch: got %+v, want %+v", got, mp)
+	}
+}
+
+func TestLineStringWKTRoundTrip(t *testing.T) {
+	ls := LineString{newPoint(52.52, 13.405), newPoint(48.8566, 2.3522)}
+
+	s := ls.MarshalWKT()
+	got, err := ParseLineStringWKT(s)
+	if err != nil {
+		t.Fatalf("ParseLineStringWKT(%q) returned error: %v", s, err)
+	}
+	if len(got) != len(ls) || got[0] != ls[0] || got[1] != ls[1] {
+		t.Errorf("round-trip mismatch: got %+v, want %+v", got, ls)
+	}
+}